@@ -0,0 +1,157 @@
+// Command task-manager wires the store, handler, and telemetry packages
+// together and serves the HTTP API. All actual logic lives in
+// internal/store and internal/handler; this file is wiring only.
+package main
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "os"
+    "os/signal"
+    "strconv"
+    "syscall"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/go-redis/redis/v8"
+    "github.com/google/uuid"
+
+    "github.com/KornilovLN/dock_comp_go/internal/handler"
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+    "github.com/KornilovLN/dock_comp_go/internal/store"
+    "github.com/KornilovLN/dock_comp_go/internal/stream"
+    "github.com/KornilovLN/dock_comp_go/internal/telemetry"
+)
+
+// knownWorkers are the workers blockTasks seeds demo tasks for; each gets its
+// own stream.Worker goroutine so those tasks actually get consumed and
+// carried through to done/failed instead of sitting at pending forever.
+var knownWorkers = []string{"worker1", "worker2", "worker3", "worker4", "worker5"}
+
+func getIntEnv(key string, defaultvaule int) int {
+    if value := os.Getenv(key); len(value) == 0 {
+        return defaultvaule
+    } else {
+        if i, err := strconv.Atoi(value); err == nil {
+            return i
+        } else {
+            return defaultvaule
+        }
+    }
+}
+
+func getStrEnv(key string, defaultValue string) string {
+    if value := os.Getenv(key); len(value) == 0 {
+        return defaultValue
+    } else {
+        return value
+    }
+}
+
+func createTask(ctx context.Context, s store.TaskStore, taskerId, workerId, name, description string) {
+    task := model.Task{
+        Id:          uuid.New().String(),
+        Name:        name,
+        Description: description,
+        Timestamp:   time.Now().Unix(),
+        TaskerId:    taskerId,
+        WorkerId:    workerId,
+    }
+    if err := s.Persist(ctx, task); err != nil {
+        fmt.Printf("Error adding task for %s: %v\n", workerId, err)
+    } else {
+        fmt.Printf("Added task for %s from %s\n", workerId, taskerId)
+    }
+}
+
+func blockTasks(s store.TaskStore) {
+    ctx := context.Background()
+
+    // Tasker1 tasks
+    createTask(ctx, s, "tsk-1", "worker1", "Task for Worker1", "Description for Worker1")
+    createTask(ctx, s, "tsk-1", "worker3", "Task for Worker3", "Description for Worker3")
+    createTask(ctx, s, "tsk-1", "worker4", "Task for Worker4 from Tasker1", "Description for Worker4 from Tasker1")
+
+    // Tasker2 tasks
+    createTask(ctx, s, "tsk-2", "worker5", "Task for Worker5", "Description for Worker5")
+
+    // Tasker3 tasks
+    createTask(ctx, s, "tsk-3", "worker2", "Task for Worker2", "Description for Worker2")
+    createTask(ctx, s, "tsk-3", "worker4", "Task for Worker4 from Tasker3", "Description for Worker4 from Tasker3")
+}
+
+// startWorkers launches one stream.Worker goroutine per known worker id,
+// each processing that worker's stream until workersCtx is cancelled. The
+// demo Handle here just simulates instantaneous success; a real deployment
+// would swap it for whatever work a task actually represents.
+func startWorkers(workersCtx context.Context, taskStore *store.RedisStore, client *redis.Client) {
+    for _, workerId := range knownWorkers {
+        worker := stream.NewWorker(client, workerId, "worker-"+workerId, func(ctx context.Context, taskId string) error {
+            return nil
+        })
+        worker.SetStatus = taskStore.SetStatus
+
+        go func(w *stream.Worker) {
+            if err := w.Run(workersCtx); err != nil && err != context.Canceled {
+                fmt.Printf("worker %s stopped: %v\n", w.WorkerId, err)
+            }
+        }(worker)
+    }
+}
+
+func main() {
+    shutdownTracing, err := telemetry.InitTracing(context.Background())
+    if err != nil {
+        fmt.Printf("tracing init: %v\n", err)
+        shutdownTracing = func(context.Context) error { return nil }
+    }
+
+    client := redis.NewClient(&redis.Options{
+        Addr:     getStrEnv("REDIS_HOST", "localhost:6379"),
+        Password: getStrEnv("REDIS_PASSWORD", ""),
+        DB:       getIntEnv("REDIS_DB", 0),
+    })
+    taskStore := store.NewRedisStore(client)
+
+    r := gin.Default()
+    handler.New(taskStore).Register(r)
+
+    blockTasks(taskStore)
+
+    workersCtx, stopWorkers := context.WithCancel(context.Background())
+    startWorkers(workersCtx, taskStore, client)
+
+    srv := &http.Server{
+        Addr:    getStrEnv("TASK_MANAGER_HOST", ":8080"),
+        Handler: r,
+    }
+
+    go func() {
+        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            fmt.Printf("listen: %v\n", err)
+        }
+    }()
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+    <-quit
+
+    stopWorkers()
+
+    gracePeriod := time.Duration(getIntEnv("SHUTDOWN_GRACE_PERIOD", 10)) * time.Second
+    ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+    defer cancel()
+
+    if err := srv.Shutdown(ctx); err != nil {
+        fmt.Printf("forced shutdown: %v\n", err)
+    }
+
+    if err := client.Close(); err != nil {
+        fmt.Printf("redis close: %v\n", err)
+    }
+
+    if err := shutdownTracing(context.Background()); err != nil {
+        fmt.Printf("tracing shutdown: %v\n", err)
+    }
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/go-playground/validator/v10"
+)
+
+// Error codes surfaced in the envelope's error.code field.
+const (
+    codeValidation  = "validation_error"
+    codeNotFound    = "not_found"
+    codeConflict    = "conflict"
+    codeInternal    = "internal_error"
+    codeUnavailable = "unavailable"
+)
+
+// apiError is the shape of the envelope's "error" field. It implements error
+// so handlers can hand it to c.Error and let errorEnvelope render it.
+type apiError struct {
+    Status  int         `json:"-"`
+    Code    string      `json:"code"`
+    Message string      `json:"message"`
+    Details interface{} `json:"details,omitempty"`
+}
+
+func (e *apiError) Error() string {
+    return e.Message
+}
+
+func newAPIError(status int, code, message string, details interface{}) *apiError {
+    return &apiError{Status: status, Code: code, Message: message, Details: details}
+}
+
+// errorEnvelope renders the last error attached via c.Error as
+// {"data":null,"error":{code,message,details}}, so every error a handler
+// reports shares one shape. Responses with no error attached (successes, and
+// gin's own 404 for an unmatched route) are left as written.
+func errorEnvelope() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        c.Next()
+
+        if len(c.Errors) == 0 {
+            return
+        }
+
+        apiErr, ok := c.Errors.Last().Err.(*apiError)
+        if !ok {
+            apiErr = newAPIError(http.StatusInternalServerError, codeInternal, c.Errors.Last().Error(), nil)
+        }
+
+        c.JSON(apiErr.Status, gin.H{"data": nil, "error": apiErr})
+    }
+}
+
+// bindingErrorDetails turns validator field errors into the
+// {field,rule,message} list the API contract promises for 422 responses.
+func bindingErrorDetails(err error) interface{} {
+    var ve validator.ValidationErrors
+    if !errors.As(err, &ve) {
+        return nil
+    }
+
+    details := make([]gin.H, 0, len(ve))
+    for _, fe := range ve {
+        details = append(details, gin.H{
+            "field":   fe.Field(),
+            "rule":    fe.Tag(),
+            "message": fmt.Sprintf("%s failed on the '%s' rule", fe.Field(), fe.Tag()),
+        })
+    }
+    return details
+}
@@ -0,0 +1,223 @@
+// Package handler wires the task manager's HTTP routes to a store.TaskStore,
+// so the transport layer has no Redis (or any other storage) dependency of
+// its own.
+package handler
+
+import (
+    "context"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+    "github.com/KornilovLN/dock_comp_go/internal/store"
+    "github.com/KornilovLN/dock_comp_go/internal/telemetry"
+)
+
+// Handler holds the dependencies every route needs.
+type Handler struct {
+    Store store.TaskStore
+}
+
+// New builds a Handler around a TaskStore.
+func New(s store.TaskStore) *Handler {
+    return &Handler{Store: s}
+}
+
+// Register mounts every route on r. requestTimeout is applied per-route
+// rather than globally so the long-poll /worker/:id/consume endpoint can use
+// its own, much longer consumeTimeout instead.
+func (h *Handler) Register(r *gin.Engine) {
+    r.Use(tracingMiddleware())
+    r.Use(metricsMiddleware())
+    r.Use(errorEnvelope())
+
+    r.GET("/metrics", requestTimeout(), gin.WrapH(telemetry.Handler()))
+
+    r.GET("/livez", requestTimeout(), h.livez)
+    r.GET("/readyz", requestTimeout(), h.readyz)
+
+    r.GET("/task", requestTimeout(), h.listTasks)
+    r.GET("/task/:id", requestTimeout(), h.getTask)
+    r.POST("/task", requestTimeout(), h.createTask)
+    r.DELETE("/task/:id", requestTimeout(), h.deleteTask)
+    r.POST("/task/:id/complete", requestTimeout(), h.completeTask)
+    r.POST("/task/:id/fail", requestTimeout(), h.failTask)
+
+    r.GET("/tasker/:id/tasks", requestTimeout(), h.listTasksByTasker)
+    r.GET("/worker/:id/tasks", requestTimeout(), h.listTasksByWorker)
+    r.GET("/worker/:id/consume", consumeTimeout(), h.consumeTask)
+}
+
+func (h *Handler) livez(c *gin.Context) {
+    c.String(http.StatusOK, "pong")
+}
+
+func (h *Handler) readyz(c *gin.Context) {
+    ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+    defer cancel()
+
+    if err := h.Store.Ping(ctx); err != nil {
+        c.Error(newAPIError(http.StatusServiceUnavailable, codeUnavailable, "redis unavailable", gin.H{"error": err.Error()}))
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"status": "ready"}, "error": nil})
+}
+
+func (h *Handler) listTasks(c *gin.Context) {
+    tasks, err := h.Store.FetchAll(c.Request.Context())
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    telemetry.TasksFetched.Inc()
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"tasks": tasks}, "error": nil})
+}
+
+func (h *Handler) getTask(c *gin.Context) {
+    id := c.Params.ByName("id")
+    task, err := h.Store.Fetch(c.Request.Context(), id)
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    if task == nil {
+        c.Error(newAPIError(http.StatusNotFound, codeNotFound, "task not found", gin.H{"id": id}))
+        return
+    }
+    telemetry.TasksFetched.Inc()
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"task": task}, "error": nil})
+}
+
+func (h *Handler) createTask(c *gin.Context) {
+    var task model.Task
+    if err := c.ShouldBindJSON(&task); err != nil {
+        c.Error(newAPIError(http.StatusUnprocessableEntity, codeValidation, "task failed validation", bindingErrorDetails(err)))
+        return
+    }
+
+    if task.Id != "" {
+        existing, err := h.Store.Fetch(c.Request.Context(), task.Id)
+        if err != nil {
+            c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+            return
+        }
+        if existing != nil {
+            c.Error(newAPIError(http.StatusConflict, codeConflict, "task already exists", gin.H{"id": task.Id}))
+            return
+        }
+    } else {
+        task.Id = uuid.New().String()
+    }
+
+    task.Timestamp = time.Now().Unix()
+    if err := h.Store.Persist(c.Request.Context(), task); err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    telemetry.TasksCreated.Inc()
+    c.JSON(http.StatusCreated, gin.H{"data": gin.H{"task": task, "created": true, "message": "Task Created Successfully"}, "error": nil})
+}
+
+func (h *Handler) deleteTask(c *gin.Context) {
+    id := c.Params.ByName("id")
+    existing, err := h.Store.Fetch(c.Request.Context(), id)
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    if existing == nil {
+        c.Error(newAPIError(http.StatusNotFound, codeNotFound, "task not found", gin.H{"id": id}))
+        return
+    }
+    if err := h.Store.Delete(c.Request.Context(), id); err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    telemetry.TasksDeleted.Inc()
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"id": id, "message": "Task deleted"}, "error": nil})
+}
+
+// completeTask reports a task claimed via /worker/:id/consume as
+// successfully handled: it acks the task's stream entry and marks it done.
+func (h *Handler) completeTask(c *gin.Context) {
+    id := c.Params.ByName("id")
+    if err := h.Store.Complete(c.Request.Context(), id); err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"id": id, "status": model.StatusDone}, "error": nil})
+}
+
+// failTask reports a task claimed via /worker/:id/consume as handled but
+// failed: it acks the task's stream entry (it won't be retried) and marks it
+// failed.
+func (h *Handler) failTask(c *gin.Context) {
+    id := c.Params.ByName("id")
+    if err := h.Store.Fail(c.Request.Context(), id); err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"id": id, "status": model.StatusFailed}, "error": nil})
+}
+
+func (h *Handler) listTasksByTasker(c *gin.Context) {
+    id := c.Params.ByName("id")
+    since, until, limit, cursor := parseListParams(c)
+    tasks, err := h.Store.FetchByTasker(c.Request.Context(), id, since, until, limit, cursor)
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    telemetry.TasksFetched.Inc()
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"tasks": tasks}, "error": nil})
+}
+
+func (h *Handler) listTasksByWorker(c *gin.Context) {
+    id := c.Params.ByName("id")
+    since, until, limit, cursor := parseListParams(c)
+    tasks, err := h.Store.FetchByWorker(c.Request.Context(), id, since, until, limit, cursor)
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    telemetry.TasksFetched.Inc()
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"tasks": tasks}, "error": nil})
+}
+
+func (h *Handler) consumeTask(c *gin.Context) {
+    workerId := c.Params.ByName("id")
+    task, err := h.Store.Consume(c.Request.Context(), workerId, "consumer-"+workerId)
+    if err != nil {
+        c.Error(newAPIError(http.StatusInternalServerError, codeInternal, err.Error(), nil))
+        return
+    }
+    if task == nil {
+        c.JSON(http.StatusNoContent, nil)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": gin.H{"task": task}, "error": nil})
+}
+
+// parseListParams reads the ?since=&until=&limit=&cursor= query params shared
+// by the secondary-index list endpoints, defaulting limit to 100 and leaving
+// since/until/cursor at 0 (unbounded / start) when absent or unparsable.
+func parseListParams(c *gin.Context) (since, until, limit, cursor int64) {
+    since = parseInt64Query(c, "since", 0)
+    until = parseInt64Query(c, "until", 0)
+    limit = parseInt64Query(c, "limit", 100)
+    cursor = parseInt64Query(c, "cursor", 0)
+    return
+}
+
+func parseInt64Query(c *gin.Context, key string, defaultValue int64) int64 {
+    if v := c.Query(key); v != "" {
+        if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+            return i
+        }
+    }
+    return defaultValue
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/KornilovLN/dock_comp_go/internal/store"
+)
+
+func newTestRouter() *gin.Engine {
+    gin.SetMode(gin.TestMode)
+    r := gin.New()
+    New(store.NewInMemoryStore()).Register(r)
+    return r
+}
+
+func TestCreateAndFetchTask(t *testing.T) {
+    r := newTestRouter()
+
+    body := `{"name":"test task","tasker_id":"tsk-1","worker_id":"worker1"}`
+    req := httptest.NewRequest(http.MethodPost, "/task", strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusCreated {
+        t.Fatalf("POST /task status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+
+    var created struct {
+        Data struct {
+            Task struct {
+                Id string `json:"id"`
+            } `json:"task"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+        t.Fatalf("unmarshal create response: %v", err)
+    }
+    if created.Data.Task.Id == "" {
+        t.Fatalf("expected a generated task id, got %+v", created)
+    }
+
+    req = httptest.NewRequest(http.MethodGet, "/task/"+created.Data.Task.Id, nil)
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusOK {
+        t.Fatalf("GET /task/:id status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+}
+
+func TestCreateTaskValidationError(t *testing.T) {
+    r := newTestRouter()
+
+    req := httptest.NewRequest(http.MethodPost, "/task", strings.NewReader(`{}`))
+    req.Header.Set("Content-Type", "application/json")
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusUnprocessableEntity {
+        t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusUnprocessableEntity, rec.Body.String())
+    }
+
+    var resp struct {
+        Error struct {
+            Code string `json:"code"`
+        } `json:"error"`
+    }
+    if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+        t.Fatalf("unmarshal error response: %v", err)
+    }
+    if resp.Error.Code != codeValidation {
+        t.Fatalf("error code = %q, want %q", resp.Error.Code, codeValidation)
+    }
+}
+
+func TestGetTaskNotFound(t *testing.T) {
+    r := newTestRouter()
+
+    req := httptest.NewRequest(http.MethodGet, "/task/does-not-exist", nil)
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusNotFound {
+        t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusNotFound, rec.Body.String())
+    }
+}
+
+// TestConsumeTaskNothingPendingReturnsNoContent checks that the consume route
+// answers 204 when no task is pending. The in-memory store's Consume returns
+// at once, so this does not exercise the route's consumeTimeout deadline.
+func TestConsumeTaskNothingPendingReturnsNoContent(t *testing.T) {
+    r := newTestRouter()
+
+    req := httptest.NewRequest(http.MethodGet, "/worker/worker1/consume", nil)
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusNoContent {
+        t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+    }
+}
+
+func TestCompleteAndFailTask(t *testing.T) {
+    r := newTestRouter()
+
+    body := `{"name":"test task","tasker_id":"tsk-1","worker_id":"worker1"}`
+    req := httptest.NewRequest(http.MethodPost, "/task", strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    rec := httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    var created struct {
+        Data struct {
+            Task struct {
+                Id string `json:"id"`
+            } `json:"task"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+        t.Fatalf("unmarshal create response: %v", err)
+    }
+    id := created.Data.Task.Id
+
+    req = httptest.NewRequest(http.MethodPost, "/task/"+id+"/complete", nil)
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+    if rec.Code != http.StatusOK {
+        t.Fatalf("POST /task/:id/complete status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+
+    req = httptest.NewRequest(http.MethodGet, "/task/"+id, nil)
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+
+    var fetched struct {
+        Data struct {
+            Task struct {
+                Status string `json:"status"`
+            } `json:"task"`
+        } `json:"data"`
+    }
+    if err := json.Unmarshal(rec.Body.Bytes(), &fetched); err != nil {
+        t.Fatalf("unmarshal fetch response: %v", err)
+    }
+    if fetched.Data.Task.Status != "done" {
+        t.Fatalf("status = %q, want %q", fetched.Data.Task.Status, "done")
+    }
+
+    req = httptest.NewRequest(http.MethodPost, "/task/"+id+"/fail", nil)
+    rec = httptest.NewRecorder()
+    r.ServeHTTP(rec, req)
+    if rec.Code != http.StatusOK {
+        t.Fatalf("POST /task/:id/fail status = %d, body = %s", rec.Code, rec.Body.String())
+    }
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+    "context"
+    "os"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/KornilovLN/dock_comp_go/internal/telemetry"
+)
+
+func getIntEnv(key string, defaultValue int) int {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+    if i, err := strconv.Atoi(value); err == nil {
+        return i
+    }
+    return defaultValue
+}
+
+// timeoutMiddleware derives a child context from c.Request.Context() bounded
+// by timeout and swaps it onto the request so every handler and store call
+// downstream inherits the deadline.
+func timeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+        defer cancel()
+
+        c.Request = c.Request.WithContext(ctx)
+        c.Next()
+    }
+}
+
+// requestTimeout bounds ordinary request handling by REQUEST_TIMEOUT seconds
+// (default 5). It's applied to every route except the long-poll consume
+// endpoint, which needs a much longer deadline and uses consumeTimeout
+// instead.
+func requestTimeout() gin.HandlerFunc {
+    return timeoutMiddleware(time.Duration(getIntEnv("REQUEST_TIMEOUT", 5)) * time.Second)
+}
+
+// consumeTimeout bounds the /worker/:id/consume long-poll by CONSUME_TIMEOUT
+// seconds (default 25), long enough to cover store.Consume's blocking stream
+// read without tripping the deadline before it has a chance to return
+// "nothing pending" on its own.
+func consumeTimeout() gin.HandlerFunc {
+    return timeoutMiddleware(time.Duration(getIntEnv("CONSUME_TIMEOUT", 25)) * time.Second)
+}
+
+// tracingMiddleware extracts a W3C traceparent from the incoming request (if
+// any) and starts a server span for the route, so this service's handlers
+// participate in a caller's distributed trace.
+func tracingMiddleware() gin.HandlerFunc {
+    propagator := otel.GetTextMapPropagator()
+
+    return func(c *gin.Context) {
+        ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+        ctx, span := telemetry.Tracer.Start(ctx, c.FullPath(), trace.WithSpanKind(trace.SpanKindServer))
+        defer span.End()
+
+        c.Request = c.Request.WithContext(ctx)
+        c.Next()
+    }
+}
+
+// metricsMiddleware records http_request_duration_seconds for every route.
+func metricsMiddleware() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+        c.Next()
+
+        telemetry.HTTPRequestDuration.
+            WithLabelValues(c.FullPath(), c.Request.Method, strconv.Itoa(c.Writer.Status())).
+            Observe(time.Since(start).Seconds())
+    }
+}
@@ -0,0 +1,21 @@
+// Package model holds the domain types shared across the store and handler
+// layers.
+package model
+
+// Task lifecycle, tracked in the Status field.
+const (
+    StatusPending = "pending"
+    StatusClaimed = "claimed"
+    StatusDone    = "done"
+    StatusFailed  = "failed"
+)
+
+type Task struct {
+    Id          string `json:"id"`
+    Name        string `json:"name" binding:"required"`
+    Description string `json:"description"`
+    Timestamp   int64  `json:"timestamp"`
+    TaskerId    string `json:"tasker_id" binding:"required"`
+    WorkerId    string `json:"worker_id" binding:"required"`
+    Status      string `json:"status"`
+}
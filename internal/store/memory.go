@@ -0,0 +1,145 @@
+package store
+
+import (
+    "context"
+    "sort"
+    "sync"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+)
+
+// InMemoryStore is a TaskStore backed by a plain map, for tests that want to
+// exercise handlers without a running Redis. It doesn't publish to a stream;
+// Consume always reports nothing pending.
+type InMemoryStore struct {
+    mu    sync.Mutex
+    tasks map[string]model.Task
+}
+
+// NewInMemoryStore builds an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+    return &InMemoryStore{tasks: make(map[string]model.Task)}
+}
+
+func (s *InMemoryStore) Persist(ctx context.Context, task model.Task) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if task.Status == "" {
+        task.Status = model.StatusPending
+    }
+    s.tasks[task.Id] = task
+    return nil
+}
+
+func (s *InMemoryStore) Fetch(ctx context.Context, id string) (*model.Task, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    task, ok := s.tasks[id]
+    if !ok {
+        return nil, nil
+    }
+    return &task, nil
+}
+
+func (s *InMemoryStore) FetchAll(ctx context.Context) ([]*model.Task, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    return s.sortedCopy(func(model.Task) bool { return true }), nil
+}
+
+func (s *InMemoryStore) Delete(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.tasks, id)
+    return nil
+}
+
+func (s *InMemoryStore) FetchByTasker(ctx context.Context, taskerId string, since, until, limit, cursor int64) ([]*model.Task, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    return s.windowed(func(t model.Task) bool { return t.TaskerId == taskerId }, since, until, limit, cursor), nil
+}
+
+func (s *InMemoryStore) FetchByWorker(ctx context.Context, workerId string, since, until, limit, cursor int64) ([]*model.Task, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    return s.windowed(func(t model.Task) bool { return t.WorkerId == workerId }, since, until, limit, cursor), nil
+}
+
+// Consume is a no-op for the in-memory store: there's no stream to drain.
+func (s *InMemoryStore) Consume(ctx context.Context, workerId, consumer string) (*model.Task, error) {
+    return nil, nil
+}
+
+// Complete sets a task's status to done, for tests that don't need a real
+// stream entry to ack.
+func (s *InMemoryStore) Complete(ctx context.Context, id string) error {
+    return s.setStatus(id, model.StatusDone)
+}
+
+// Fail sets a task's status to failed, for tests that don't need a real
+// stream entry to ack.
+func (s *InMemoryStore) Fail(ctx context.Context, id string) error {
+    return s.setStatus(id, model.StatusFailed)
+}
+
+func (s *InMemoryStore) setStatus(id, status string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    task, ok := s.tasks[id]
+    if !ok {
+        return nil
+    }
+    task.Status = status
+    s.tasks[id] = task
+    return nil
+}
+
+// Ping always succeeds: there's no backing service to be unready.
+func (s *InMemoryStore) Ping(ctx context.Context) error {
+    return nil
+}
+
+func (s *InMemoryStore) windowed(match func(model.Task) bool, since, until, limit, cursor int64) []*model.Task {
+    tasks := s.sortedCopy(func(t model.Task) bool {
+        if !match(t) {
+            return false
+        }
+        if since > 0 && t.Timestamp < since {
+            return false
+        }
+        if until > 0 && t.Timestamp > until {
+            return false
+        }
+        return true
+    })
+
+    if cursor >= int64(len(tasks)) {
+        return []*model.Task{}
+    }
+    tasks = tasks[cursor:]
+
+    if limit > 0 && int64(len(tasks)) > limit {
+        tasks = tasks[:limit]
+    }
+    return tasks
+}
+
+func (s *InMemoryStore) sortedCopy(match func(model.Task) bool) []*model.Task {
+    tasks := make([]*model.Task, 0, len(s.tasks))
+    for _, t := range s.tasks {
+        t := t
+        if match(t) {
+            tasks = append(tasks, &t)
+        }
+    }
+    sort.Slice(tasks, func(i, j int) bool { return tasks[i].Timestamp < tasks[j].Timestamp })
+    return tasks
+}
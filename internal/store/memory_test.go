@@ -0,0 +1,68 @@
+package store
+
+import (
+    "context"
+    "testing"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+)
+
+func TestInMemoryStorePersistFetchDelete(t *testing.T) {
+    ctx := context.Background()
+    s := NewInMemoryStore()
+
+    task := model.Task{Id: "task-1", Name: "test", TaskerId: "tsk-1", WorkerId: "worker1", Timestamp: 100}
+    if err := s.Persist(ctx, task); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    got, err := s.Fetch(ctx, "task-1")
+    if err != nil {
+        t.Fatalf("Fetch: %v", err)
+    }
+    if got == nil || got.Status != model.StatusPending {
+        t.Fatalf("got %+v, want a pending task", got)
+    }
+
+    if err := s.Delete(ctx, "task-1"); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+
+    got, err = s.Fetch(ctx, "task-1")
+    if err != nil {
+        t.Fatalf("Fetch after delete: %v", err)
+    }
+    if got != nil {
+        t.Fatalf("got %+v, want nil after delete", got)
+    }
+}
+
+func TestInMemoryStoreFetchByTaskerWindowAndPaginate(t *testing.T) {
+    ctx := context.Background()
+    s := NewInMemoryStore()
+
+    for i, ts := range []int64{10, 20, 30, 40} {
+        task := model.Task{
+            Id:        []string{"a", "b", "c", "d"}[i],
+            Name:      "test",
+            TaskerId:  "tsk-1",
+            WorkerId:  "worker1",
+            Timestamp: ts,
+        }
+        if err := s.Persist(ctx, task); err != nil {
+            t.Fatalf("Persist: %v", err)
+        }
+    }
+    // Task from a different tasker shouldn't show up in the tsk-1 window.
+    if err := s.Persist(ctx, model.Task{Id: "e", Name: "test", TaskerId: "tsk-2", WorkerId: "worker1", Timestamp: 25}); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    tasks, err := s.FetchByTasker(ctx, "tsk-1", 15, 35, 1, 1)
+    if err != nil {
+        t.Fatalf("FetchByTasker: %v", err)
+    }
+    if len(tasks) != 1 || tasks[0].Id != "c" {
+        t.Fatalf("got %+v, want [{Id: c}]", tasks)
+    }
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/go-redis/redis/v8"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+    "github.com/KornilovLN/dock_comp_go/internal/stream"
+)
+
+func newTestRedisStore(t *testing.T) *RedisStore {
+    t.Helper()
+
+    mr, err := miniredis.Run()
+    if err != nil {
+        t.Fatalf("miniredis.Run: %v", err)
+    }
+    t.Cleanup(mr.Close)
+
+    return NewRedisStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+}
+
+func TestRedisStorePersistFetchDelete(t *testing.T) {
+    ctx := context.Background()
+    s := newTestRedisStore(t)
+
+    task := model.Task{Id: "task-1", Name: "test", TaskerId: "tsk-1", WorkerId: "worker1", Timestamp: 100}
+    if err := s.Persist(ctx, task); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    got, err := s.Fetch(ctx, "task-1")
+    if err != nil {
+        t.Fatalf("Fetch: %v", err)
+    }
+    if got == nil || got.Status != model.StatusPending {
+        t.Fatalf("got %+v, want a pending task", got)
+    }
+
+    all, err := s.FetchAll(ctx)
+    if err != nil {
+        t.Fatalf("FetchAll: %v", err)
+    }
+    if len(all) != 1 || all[0].Id != "task-1" {
+        t.Fatalf("FetchAll = %+v, want one task-1", all)
+    }
+
+    if err := s.Delete(ctx, "task-1"); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+
+    got, err = s.Fetch(ctx, "task-1")
+    if err != nil {
+        t.Fatalf("Fetch after delete: %v", err)
+    }
+    if got != nil {
+        t.Fatalf("got %+v, want nil after delete", got)
+    }
+
+    all, err = s.FetchAll(ctx)
+    if err != nil {
+        t.Fatalf("FetchAll after delete: %v", err)
+    }
+    if len(all) != 0 {
+        t.Fatalf("FetchAll after delete = %+v, want none", all)
+    }
+}
+
+func TestRedisStoreDeleteRemovesFromSecondaryIndexes(t *testing.T) {
+    ctx := context.Background()
+    s := newTestRedisStore(t)
+
+    task := model.Task{Id: "task-1", Name: "test", TaskerId: "tsk-1", WorkerId: "worker1", Timestamp: 100}
+    if err := s.Persist(ctx, task); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    byTasker, err := s.FetchByTasker(ctx, "tsk-1", 0, 0, 100, 0)
+    if err != nil {
+        t.Fatalf("FetchByTasker: %v", err)
+    }
+    if len(byTasker) != 1 {
+        t.Fatalf("FetchByTasker before delete = %+v, want one entry", byTasker)
+    }
+
+    byWorker, err := s.FetchByWorker(ctx, "worker1", 0, 0, 100, 0)
+    if err != nil {
+        t.Fatalf("FetchByWorker: %v", err)
+    }
+    if len(byWorker) != 1 {
+        t.Fatalf("FetchByWorker before delete = %+v, want one entry", byWorker)
+    }
+
+    if err := s.Delete(ctx, "task-1"); err != nil {
+        t.Fatalf("Delete: %v", err)
+    }
+
+    byTasker, err = s.FetchByTasker(ctx, "tsk-1", 0, 0, 100, 0)
+    if err != nil {
+        t.Fatalf("FetchByTasker after delete: %v", err)
+    }
+    if len(byTasker) != 0 {
+        t.Fatalf("FetchByTasker after delete = %+v, want none", byTasker)
+    }
+
+    byWorker, err = s.FetchByWorker(ctx, "worker1", 0, 0, 100, 0)
+    if err != nil {
+        t.Fatalf("FetchByWorker after delete: %v", err)
+    }
+    if len(byWorker) != 0 {
+        t.Fatalf("FetchByWorker after delete = %+v, want none", byWorker)
+    }
+}
+
+func TestRedisStoreFetchByTaskerAndWorkerWindowAndPaginate(t *testing.T) {
+    ctx := context.Background()
+    s := newTestRedisStore(t)
+
+    for i, ts := range []int64{10, 20, 30, 40} {
+        task := model.Task{
+            Id:        []string{"a", "b", "c", "d"}[i],
+            Name:      "test",
+            TaskerId:  "tsk-1",
+            WorkerId:  "worker1",
+            Timestamp: ts,
+        }
+        if err := s.Persist(ctx, task); err != nil {
+            t.Fatalf("Persist: %v", err)
+        }
+    }
+    // A different tasker/worker pair shouldn't show up in either window.
+    if err := s.Persist(ctx, model.Task{Id: "e", Name: "test", TaskerId: "tsk-2", WorkerId: "worker2", Timestamp: 25}); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    byTasker, err := s.FetchByTasker(ctx, "tsk-1", 15, 35, 1, 1)
+    if err != nil {
+        t.Fatalf("FetchByTasker: %v", err)
+    }
+    if len(byTasker) != 1 || byTasker[0].Id != "c" {
+        t.Fatalf("FetchByTasker = %+v, want [{Id: c}]", byTasker)
+    }
+
+    byWorker, err := s.FetchByWorker(ctx, "worker1", 0, 0, 100, 0)
+    if err != nil {
+        t.Fatalf("FetchByWorker: %v", err)
+    }
+    if len(byWorker) != 4 {
+        t.Fatalf("FetchByWorker = %+v, want 4 entries", byWorker)
+    }
+}
+
+func TestRedisStoreConsumeClaimsAndCompleteAcks(t *testing.T) {
+    ctx := context.Background()
+    s := newTestRedisStore(t)
+
+    task := model.Task{Id: "task-1", Name: "test", TaskerId: "tsk-1", WorkerId: "worker1", Timestamp: 100}
+    if err := s.Persist(ctx, task); err != nil {
+        t.Fatalf("Persist: %v", err)
+    }
+
+    consumed, err := s.Consume(ctx, "worker1", "consumer-1")
+    if err != nil {
+        t.Fatalf("Consume: %v", err)
+    }
+    if consumed == nil || consumed.Id != "task-1" {
+        t.Fatalf("Consume = %+v, want task-1", consumed)
+    }
+    if consumed.Status != model.StatusClaimed {
+        t.Fatalf("status after Consume = %q, want %q", consumed.Status, model.StatusClaimed)
+    }
+
+    if err := s.Complete(ctx, "task-1"); err != nil {
+        t.Fatalf("Complete: %v", err)
+    }
+
+    got, err := s.Fetch(ctx, "task-1")
+    if err != nil {
+        t.Fatalf("Fetch: %v", err)
+    }
+    if got.Status != model.StatusDone {
+        t.Fatalf("status after Complete = %q, want %q", got.Status, model.StatusDone)
+    }
+
+    pending, err := s.Client.XPending(ctx, stream.KeyForWorker("worker1"), stream.Group).Result()
+    if err != nil {
+        t.Fatalf("XPending: %v", err)
+    }
+    if pending.Count != 0 {
+        t.Fatalf("pending entries after Complete = %d, want 0", pending.Count)
+    }
+}
+
+func TestRedisStoreConsumeNothingPendingReturnsNil(t *testing.T) {
+    ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+    defer cancel()
+    s := newTestRedisStore(t)
+
+    task, err := s.Consume(ctx, "worker1", "consumer-1")
+    if err != nil {
+        t.Fatalf("Consume: %v", err)
+    }
+    if task != nil {
+        t.Fatalf("Consume = %+v, want nil", task)
+    }
+}
@@ -0,0 +1,322 @@
+// Package store persists tasks and exposes them through the TaskStore
+// interface, so handlers don't depend on Redis directly.
+package store
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+    "github.com/KornilovLN/dock_comp_go/internal/stream"
+    "github.com/KornilovLN/dock_comp_go/internal/telemetry"
+)
+
+// TaskStore is the persistence contract handlers depend on. RedisStore is the
+// production implementation; InMemoryStore backs tests that shouldn't need a
+// running Redis.
+type TaskStore interface {
+    Persist(ctx context.Context, task model.Task) error
+    Fetch(ctx context.Context, id string) (*model.Task, error)
+    FetchAll(ctx context.Context) ([]*model.Task, error)
+    Delete(ctx context.Context, id string) error
+    FetchByTasker(ctx context.Context, taskerId string, since, until, limit, cursor int64) ([]*model.Task, error)
+    FetchByWorker(ctx context.Context, workerId string, since, until, limit, cursor int64) ([]*model.Task, error)
+    Consume(ctx context.Context, workerId, consumer string) (*model.Task, error)
+    Complete(ctx context.Context, id string) error
+    Fail(ctx context.Context, id string) error
+    Ping(ctx context.Context) error
+}
+
+// RedisStore is the TaskStore backed by Redis: a hash per task, a global
+// ZSET, and the by_tasker/by_worker secondary index ZSETs, plus the Redis
+// Streams dispatch from the stream package.
+type RedisStore struct {
+    Client *redis.Client
+}
+
+// NewRedisStore builds a RedisStore around an existing Redis client.
+func NewRedisStore(client *redis.Client) *RedisStore {
+    return &RedisStore{Client: client}
+}
+
+// fieldStreamMsgId is the extra hash field Consume stashes a claimed task's
+// stream entry ID under, so Complete/Fail can ack it later without the
+// caller having to track the ID itself. It's not part of model.Task, so it
+// never surfaces in API responses.
+const fieldStreamMsgId = "StreamMsgId"
+
+func taskerIndexKey(taskerId string) string {
+    return fmt.Sprintf("tasks:by_tasker:%s", taskerId)
+}
+
+func workerIndexKey(workerId string) string {
+    return fmt.Sprintf("tasks:by_worker:%s", workerId)
+}
+
+func (s *RedisStore) Persist(ctx context.Context, task model.Task) error {
+    ctx, span := telemetry.Tracer.Start(ctx, "store.Persist")
+    defer span.End()
+
+    if task.Status == "" {
+        task.Status = model.StatusPending
+    }
+
+    z := redis.Z{Score: float64(task.Timestamp), Member: task.Id}
+
+    pipe := s.Client.TxPipeline()
+    pipe.HSet(ctx, fmt.Sprintf("task:%s", task.Id),
+        "Id", task.Id,
+        "Name", task.Name,
+        "Description", task.Description,
+        "Timestamp", task.Timestamp,
+        "TaskerId", task.TaskerId,
+        "WorkerId", task.WorkerId,
+        "Status", task.Status,
+    )
+    pipe.ZAdd(ctx, "tasks", &z)
+    pipe.ZAdd(ctx, taskerIndexKey(task.TaskerId), &z)
+    pipe.ZAdd(ctx, workerIndexKey(task.WorkerId), &z)
+
+    if _, err := pipe.Exec(ctx); err != nil {
+        return err
+    }
+
+    if err := stream.Publish(ctx, s.Client, task.WorkerId, task.Id); err != nil {
+        return err
+    }
+
+    s.updateBacklogGauge(ctx, task.WorkerId)
+    return nil
+}
+
+func (s *RedisStore) Fetch(ctx context.Context, id string) (*model.Task, error) {
+    ctx, span := telemetry.Tracer.Start(ctx, "store.Fetch")
+    defer span.End()
+
+    ires, err := s.Client.HGetAll(ctx, fmt.Sprintf("task:%s", id)).Result()
+    if err != nil {
+        return nil, err
+    }
+
+    if len(ires) == 0 {
+        return nil, nil
+    }
+
+    timestamp, _ := strconv.ParseInt(ires["Timestamp"], 10, 64)
+    task := model.Task{
+        Id:          ires["Id"],
+        Name:        ires["Name"],
+        Description: ires["Description"],
+        Timestamp:   timestamp,
+        TaskerId:    ires["TaskerId"],
+        WorkerId:    ires["WorkerId"],
+        Status:      ires["Status"],
+    }
+    return &task, nil
+}
+
+func (s *RedisStore) FetchAll(ctx context.Context) ([]*model.Task, error) {
+    ctx, span := telemetry.Tracer.Start(ctx, "store.FetchAll")
+    defer span.End()
+
+    ids, err := s.Client.ZRange(ctx, "tasks", 0, -1).Result()
+    if err != nil {
+        return nil, err
+    }
+
+    tasks := make([]*model.Task, 0, len(ids))
+    for _, id := range ids {
+        task, err := s.Fetch(ctx, id)
+        if err != nil {
+            return nil, err
+        }
+        tasks = append(tasks, task)
+    }
+    return tasks, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+    ctx, span := telemetry.Tracer.Start(ctx, "store.Delete")
+    defer span.End()
+
+    task, err := s.Fetch(ctx, id)
+    if err != nil {
+        return err
+    }
+    if task == nil {
+        return nil
+    }
+
+    pipe := s.Client.TxPipeline()
+    pipe.Unlink(ctx, fmt.Sprintf("task:%s", id))
+    pipe.ZRem(ctx, "tasks", id)
+    pipe.ZRem(ctx, taskerIndexKey(task.TaskerId), id)
+    pipe.ZRem(ctx, workerIndexKey(task.WorkerId), id)
+
+    _, err = pipe.Exec(ctx)
+    return err
+}
+
+// fetchByIndex serves the time-windowed, paginated by-tasker/by-worker
+// endpoints against one of the secondary index ZSETs. since/until of 0 mean
+// unbounded, cursor is the ZRANGEBYSCORE offset to resume from, and limit
+// caps the page size.
+func (s *RedisStore) fetchByIndex(ctx context.Context, indexKey string, since, until, limit, cursor int64) ([]*model.Task, error) {
+    min := "-inf"
+    if since > 0 {
+        min = strconv.FormatInt(since, 10)
+    }
+    max := "+inf"
+    if until > 0 {
+        max = strconv.FormatInt(until, 10)
+    }
+
+    ids, err := s.Client.ZRangeByScore(ctx, indexKey, &redis.ZRangeBy{
+        Min:    min,
+        Max:    max,
+        Offset: cursor,
+        Count:  limit,
+    }).Result()
+    if err != nil {
+        return nil, err
+    }
+
+    tasks := make([]*model.Task, 0, len(ids))
+    for _, id := range ids {
+        task, err := s.Fetch(ctx, id)
+        if err != nil {
+            return nil, err
+        }
+        tasks = append(tasks, task)
+    }
+    return tasks, nil
+}
+
+func (s *RedisStore) FetchByTasker(ctx context.Context, taskerId string, since, until, limit, cursor int64) ([]*model.Task, error) {
+    return s.fetchByIndex(ctx, taskerIndexKey(taskerId), since, until, limit, cursor)
+}
+
+func (s *RedisStore) FetchByWorker(ctx context.Context, workerId string, since, until, limit, cursor int64) ([]*model.Task, error) {
+    return s.fetchByIndex(ctx, workerIndexKey(workerId), since, until, limit, cursor)
+}
+
+// consumeBlock is how long Consume blocks waiting for a task when ctx
+// carries no deadline of its own (e.g. a caller other than the HTTP handler).
+const consumeBlock = 20 * time.Second
+
+// Consume claims the next pending task from a worker's stream and marks it
+// claimed, for the /worker/:id/consume long-poll endpoint. It returns nil
+// (no error) if nothing was available before the long-poll window elapsed.
+//
+// The stream entry itself is NOT acked here: the caller is now responsible
+// for reporting back through Complete or Fail, which ack it. An entry the
+// caller never reports back on (e.g. because it crashed) stays pending and
+// is reclaimed by a stream.Worker's auto-claim once it's gone idle.
+func (s *RedisStore) Consume(ctx context.Context, workerId, consumer string) (*model.Task, error) {
+    taskId, msgId, err := stream.ConsumeOne(ctx, s.Client, workerId, consumer, blockDuration(ctx, consumeBlock))
+    if err != nil {
+        return nil, err
+    }
+    if taskId == "" {
+        return nil, nil
+    }
+
+    if err := s.Client.HSet(ctx, fmt.Sprintf("task:%s", taskId),
+        "Status", model.StatusClaimed,
+        fieldStreamMsgId, msgId,
+    ).Err(); err != nil {
+        return nil, err
+    }
+
+    s.updateBacklogGauge(ctx, workerId)
+    return s.Fetch(ctx, taskId)
+}
+
+// Complete acks a claimed task's stream entry and marks it done, for the
+// caller of /worker/:id/consume to report success.
+func (s *RedisStore) Complete(ctx context.Context, id string) error {
+    return s.finishClaim(ctx, id, model.StatusDone)
+}
+
+// Fail acks a claimed task's stream entry and marks it failed, for the
+// caller of /worker/:id/consume to report a failure it won't retry itself.
+// Unlike a stream.Worker's Handle returning an error, this acks rather than
+// leaving the entry pending: the caller has already told us definitively
+// it's done trying.
+func (s *RedisStore) Fail(ctx context.Context, id string) error {
+    return s.finishClaim(ctx, id, model.StatusFailed)
+}
+
+// finishClaim acks the stream entry a task was claimed under (if it still
+// has one on record) and sets its terminal status.
+func (s *RedisStore) finishClaim(ctx context.Context, id, status string) error {
+    key := fmt.Sprintf("task:%s", id)
+
+    task, err := s.Fetch(ctx, id)
+    if err != nil {
+        return err
+    }
+    if task == nil {
+        return nil
+    }
+
+    msgId, err := s.Client.HGet(ctx, key, fieldStreamMsgId).Result()
+    if err != nil && err != redis.Nil {
+        return err
+    }
+    if msgId != "" {
+        if err := stream.Ack(ctx, s.Client, task.WorkerId, msgId); err != nil {
+            return err
+        }
+    }
+
+    return s.Client.HSet(ctx, key, "Status", status).Err()
+}
+
+// SetStatus updates just a task's lifecycle status field, for callers (like
+// a stream.Worker tracking done/failed outcomes) that don't need to touch
+// the rest of the hash. It satisfies stream.StatusSetter.
+func (s *RedisStore) SetStatus(ctx context.Context, id, status string) error {
+    return s.Client.HSet(ctx, fmt.Sprintf("task:%s", id), "Status", status).Err()
+}
+
+// blockDuration picks how long a blocking stream read should wait: the
+// remaining ctx deadline minus a safety margin (so Redis has a chance to
+// return "nothing pending" before ctx itself expires), capped at fallback, or
+// fallback if ctx carries no deadline. It never returns less than 1ms, since
+// 0 would block forever. Without this, a blocking read longer than the
+// caller's deadline surfaces as a context-deadline error instead of the
+// caller's intended "nothing available" result.
+func blockDuration(ctx context.Context, fallback time.Duration) time.Duration {
+    deadline, ok := ctx.Deadline()
+    if !ok {
+        return fallback
+    }
+
+    const margin = time.Second
+    remaining := time.Until(deadline) - margin
+    if remaining <= 0 {
+        return time.Millisecond
+    }
+    if remaining < fallback {
+        return remaining
+    }
+    return fallback
+}
+
+// updateBacklogGauge refreshes tasks_stream_backlog for a worker. Failures
+// are swallowed: a stale metric is better than failing the request over it.
+func (s *RedisStore) updateBacklogGauge(ctx context.Context, workerId string) {
+    if n, err := stream.Backlog(ctx, s.Client, workerId); err == nil {
+        telemetry.StreamBacklog.WithLabelValues(workerId).Set(float64(n))
+    }
+}
+
+// Ping checks Redis reachability for the /readyz endpoint.
+func (s *RedisStore) Ping(ctx context.Context) error {
+    return s.Client.Ping(ctx).Err()
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestBlockDurationNoDeadlineUsesFallback(t *testing.T) {
+    got := blockDuration(context.Background(), 20*time.Second)
+    if got != 20*time.Second {
+        t.Fatalf("got %v, want fallback of 20s", got)
+    }
+}
+
+func TestBlockDurationCapsToRemainingDeadline(t *testing.T) {
+    ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+    defer cancel()
+
+    got := blockDuration(ctx, 20*time.Second)
+    if got <= 0 || got >= 3*time.Second {
+        t.Fatalf("got %v, want something less than the 3s deadline and greater than zero", got)
+    }
+}
+
+func TestBlockDurationExpiredDeadlineNeverBlocksForever(t *testing.T) {
+    ctx, cancel := context.WithTimeout(context.Background(), -1*time.Second)
+    defer cancel()
+
+    got := blockDuration(ctx, 20*time.Second)
+    if got <= 0 {
+        t.Fatalf("got %v, want a small positive duration, never 0 (which means block forever)", got)
+    }
+}
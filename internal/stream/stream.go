@@ -0,0 +1,284 @@
+// Package stream implements task dispatch over Redis Streams: one stream per
+// worker, consumed through a consumer group so that crashed workers don't
+// strand in-flight tasks.
+package stream
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+)
+
+// Group is the consumer group name shared by all workers; each worker only
+// ever reads from its own stream, so collisions across workers aren't a
+// concern.
+const Group = "workers"
+
+// KeyForWorker returns the stream key a worker's tasks are published to.
+func KeyForWorker(workerId string) string {
+    return fmt.Sprintf("tasks:stream:%s", workerId)
+}
+
+// Publish appends a task id to its worker's stream, creating the stream (and
+// consumer group) on first use.
+func Publish(ctx context.Context, client *redis.Client, workerId, taskId string) error {
+    key := KeyForWorker(workerId)
+
+    if err := ensureGroup(ctx, client, key); err != nil {
+        return err
+    }
+
+    return client.XAdd(ctx, &redis.XAddArgs{
+        Stream: key,
+        Values: map[string]interface{}{"task_id": taskId},
+    }).Err()
+}
+
+// Ack acknowledges a claimed stream entry, removing it from the consumer
+// group's pending list. Callers that claim an entry outside of Worker.Run
+// (like store.RedisStore, on behalf of /worker/:id/consume) use this once
+// they know the task succeeded or has been given up on.
+func Ack(ctx context.Context, client *redis.Client, workerId, msgId string) error {
+    return client.XAck(ctx, KeyForWorker(workerId), Group, msgId).Err()
+}
+
+func ensureGroup(ctx context.Context, client *redis.Client, key string) error {
+    err := client.XGroupCreateMkStream(ctx, key, Group, "0").Err()
+    if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+        return err
+    }
+    return nil
+}
+
+// Handler processes a claimed task id. Returning an error leaves the entry
+// unacknowledged so it becomes eligible for auto-claim.
+type Handler func(ctx context.Context, taskId string) error
+
+// StatusSetter records a task's lifecycle status, e.g. flipping the task
+// hash to done/failed after Worker.process handles it. It's a function type
+// rather than a dependency on internal/store so this package doesn't need to
+// import store; store.RedisStore.SetStatus satisfies it as-is.
+type StatusSetter func(ctx context.Context, taskId, status string) error
+
+// Worker consumes a single worker's stream under the shared consumer group,
+// acking on success and reclaiming entries that have sat idle past
+// IdleThreshold (e.g. because the consumer that read them crashed, or because
+// an HTTP caller that claimed one via /worker/:id/consume never reported
+// back).
+type Worker struct {
+    Client        *redis.Client
+    WorkerId      string
+    Consumer      string
+    IdleThreshold time.Duration
+    Handle        Handler
+
+    // SetStatus is optional: if nil, Worker.process skips status tracking
+    // (e.g. for tests that only care about stream delivery).
+    SetStatus StatusSetter
+}
+
+// NewWorker builds a Worker with a sane default idle threshold.
+func NewWorker(client *redis.Client, workerId, consumer string, handle Handler) *Worker {
+    return &Worker{
+        Client:        client,
+        WorkerId:      workerId,
+        Consumer:      consumer,
+        IdleThreshold: 30 * time.Second,
+        Handle:        handle,
+    }
+}
+
+// Run joins the consumer group for the worker's stream and processes entries
+// until ctx is cancelled. Each round first auto-claims entries that have been
+// pending longer than IdleThreshold (including ones left over from a previous
+// crash, whichever consumer read them), then blocks up to 5s for new ones.
+// Pending entries not yet idle that long are left for a later round.
+func (w *Worker) Run(ctx context.Context) error {
+    key := KeyForWorker(w.WorkerId)
+
+    if err := ensureGroup(ctx, w.Client, key); err != nil {
+        return err
+    }
+
+    for {
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        default:
+        }
+
+        if err := w.claimIdle(ctx, key); err != nil {
+            return err
+        }
+
+        streams, err := w.Client.XReadGroup(ctx, &redis.XReadGroupArgs{
+            Group:    Group,
+            Consumer: w.Consumer,
+            Streams:  []string{key, ">"},
+            Count:    10,
+            Block:    5 * time.Second,
+        }).Result()
+
+        if err == redis.Nil {
+            continue
+        }
+        if err != nil {
+            return err
+        }
+
+        for _, stream := range streams {
+            for _, msg := range stream.Messages {
+                if err := w.process(ctx, key, msg); err != nil {
+                    return err
+                }
+            }
+        }
+    }
+}
+
+func (w *Worker) process(ctx context.Context, key string, msg redis.XMessage) error {
+    taskId, _ := msg.Values["task_id"].(string)
+
+    if err := w.Handle(ctx, taskId); err != nil {
+        w.setStatus(ctx, taskId, model.StatusFailed)
+        return nil // leave unacked; auto-claim will retry it
+    }
+
+    if err := w.Client.XAck(ctx, key, Group, msg.ID).Err(); err != nil {
+        return err
+    }
+
+    w.setStatus(ctx, taskId, model.StatusDone)
+    return nil
+}
+
+// setStatus records taskId's lifecycle transition if the Worker was given a
+// StatusSetter. Errors are swallowed: a stale status on the task hash
+// shouldn't stop the stream from making progress.
+func (w *Worker) setStatus(ctx context.Context, taskId, status string) {
+    if w.SetStatus == nil {
+        return
+    }
+    _ = w.SetStatus(ctx, taskId, status)
+}
+
+// Backlog returns the number of entries currently in a worker's stream,
+// regardless of delivery state, for the tasks_stream_backlog gauge.
+func Backlog(ctx context.Context, client *redis.Client, workerId string) (int64, error) {
+    return client.XLen(ctx, KeyForWorker(workerId)).Result()
+}
+
+// ConsumeOne performs a single blocking read of a worker's stream under the
+// shared consumer group, for callers (like an HTTP long-poll endpoint) that
+// want one entry at a time rather than running a full Worker loop. Unlike
+// Worker.process, it does NOT ack the entry: responsibility for it passes to
+// the caller, who acks (via Ack) once it reports success or failure. An
+// entry that's never reported back stays pending and is picked up by a
+// Worker's auto-claim once it's gone idle. Returns "", "", nil if block
+// elapses with nothing to read.
+func ConsumeOne(ctx context.Context, client *redis.Client, workerId, consumer string, block time.Duration) (taskId, msgId string, err error) {
+    key := KeyForWorker(workerId)
+
+    if err := ensureGroup(ctx, client, key); err != nil {
+        return "", "", err
+    }
+
+    streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+        Group:    Group,
+        Consumer: consumer,
+        Streams:  []string{key, ">"},
+        Count:    1,
+        Block:    block,
+    }).Result()
+
+    if err == redis.Nil {
+        return "", "", nil
+    }
+    if err != nil {
+        return "", "", err
+    }
+
+    for _, s := range streams {
+        for _, msg := range s.Messages {
+            taskId, _ := msg.Values["task_id"].(string)
+            return taskId, msg.ID, nil
+        }
+    }
+
+    return "", "", nil
+}
+
+// claimIdle reclaims entries that have been pending for longer than
+// IdleThreshold, regardless of which consumer originally read them (a
+// crashed Worker consumer, or an HTTP caller that claimed one via
+// /worker/:id/consume and never reported back), and runs them through the
+// same Handle/ack/status path as freshly read entries.
+func (w *Worker) claimIdle(ctx context.Context, key string) error {
+    messages, err := xAutoClaim(ctx, w.Client, key, Group, w.Consumer, w.IdleThreshold, "0", 10)
+    if err != nil {
+        return err
+    }
+
+    for _, msg := range messages {
+        if err := w.process(ctx, key, msg); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// xAutoClaim issues XAUTOCLAIM directly via client.Do rather than go-redis
+// v8's XAutoClaim: that client's reply parser hard-codes a 2-element
+// [cursor, messages] array and errors ("got 3, wanted 2") against the
+// 3-element [cursor, messages, deleted-ids] reply every Redis >=7.0 server
+// (and miniredis, which emulates it) actually sends.
+func xAutoClaim(ctx context.Context, client *redis.Client, key, group, consumer string, minIdle time.Duration, start string, count int64) ([]redis.XMessage, error) {
+    reply, err := client.Do(ctx, "XAUTOCLAIM", key, group, consumer, formatMs(minIdle), start, "COUNT", count).Result()
+    if err == redis.Nil {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    fields, ok := reply.([]interface{})
+    if !ok || len(fields) < 2 {
+        return nil, fmt.Errorf("stream: unexpected XAUTOCLAIM reply %T", reply)
+    }
+
+    entries, ok := fields[1].([]interface{})
+    if !ok {
+        return nil, fmt.Errorf("stream: unexpected XAUTOCLAIM messages reply %T", fields[1])
+    }
+
+    messages := make([]redis.XMessage, 0, len(entries))
+    for _, e := range entries {
+        entry, ok := e.([]interface{})
+        if !ok || len(entry) != 2 {
+            continue
+        }
+
+        id, _ := entry[0].(string)
+        rawValues, _ := entry[1].([]interface{})
+
+        values := make(map[string]interface{}, len(rawValues)/2)
+        for i := 0; i+1 < len(rawValues); i += 2 {
+            field, _ := rawValues[i].(string)
+            values[field] = rawValues[i+1]
+        }
+
+        messages = append(messages, redis.XMessage{ID: id, Values: values})
+    }
+
+    return messages, nil
+}
+
+// formatMs renders a duration as the integer milliseconds XAUTOCLAIM's
+// min-idle-time argument expects.
+func formatMs(d time.Duration) int64 {
+    return d.Milliseconds()
+}
@@ -0,0 +1,182 @@
+package stream
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/alicebob/miniredis/v2"
+    "github.com/go-redis/redis/v8"
+
+    "github.com/KornilovLN/dock_comp_go/internal/model"
+)
+
+func newTestClient(t *testing.T) *redis.Client {
+    t.Helper()
+
+    mr, err := miniredis.Run()
+    if err != nil {
+        t.Fatalf("miniredis.Run: %v", err)
+    }
+    t.Cleanup(mr.Close)
+
+    return redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestPublishAndRun(t *testing.T) {
+    client := newTestClient(t)
+    ctx := context.Background()
+
+    if err := Publish(ctx, client, "worker1", "task-1"); err != nil {
+        t.Fatalf("Publish: %v", err)
+    }
+
+    processed := make(chan string, 1)
+    worker := NewWorker(client, "worker1", "consumer-1", func(ctx context.Context, taskId string) error {
+        processed <- taskId
+        return nil
+    })
+
+    runCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+    defer cancel()
+
+    go worker.Run(runCtx)
+
+    select {
+    case taskId := <-processed:
+        if taskId != "task-1" {
+            t.Fatalf("got task id %q, want %q", taskId, "task-1")
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatal("timed out waiting for task to be processed")
+    }
+}
+
+func TestClaimIdleReclaimsAbandonedEntry(t *testing.T) {
+    client := newTestClient(t)
+    ctx := context.Background()
+
+    if err := Publish(ctx, client, "worker2", "task-2"); err != nil {
+        t.Fatalf("Publish: %v", err)
+    }
+
+    // Simulate a crashed consumer that read but never acked.
+    key := KeyForWorker("worker2")
+    if err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+        Group:    Group,
+        Consumer: "dead-consumer",
+        Streams:  []string{key, ">"},
+        Count:    1,
+    }).Err(); err != nil {
+        t.Fatalf("XReadGroup: %v", err)
+    }
+
+    processed := make(chan string, 1)
+    worker := NewWorker(client, "worker2", "consumer-2", func(ctx context.Context, taskId string) error {
+        processed <- taskId
+        return nil
+    })
+    worker.IdleThreshold = 0
+
+    runCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+    defer cancel()
+
+    go worker.Run(runCtx)
+
+    select {
+    case taskId := <-processed:
+        if taskId != "task-2" {
+            t.Fatalf("got task id %q, want %q", taskId, "task-2")
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatal("timed out waiting for idle entry to be reclaimed")
+    }
+}
+
+// fakeStatusSetter records status transitions in memory, standing in for
+// store.RedisStore.SetStatus.
+type fakeStatusSetter struct {
+    mu       sync.Mutex
+    statuses map[string]string
+}
+
+func newFakeStatusSetter() *fakeStatusSetter {
+    return &fakeStatusSetter{statuses: make(map[string]string)}
+}
+
+func (f *fakeStatusSetter) set(ctx context.Context, taskId, status string) error {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.statuses[taskId] = status
+    return nil
+}
+
+func (f *fakeStatusSetter) get(taskId string) string {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return f.statuses[taskId]
+}
+
+func TestProcessMarksStatusDoneOnSuccess(t *testing.T) {
+    client := newTestClient(t)
+    ctx := context.Background()
+
+    if err := Publish(ctx, client, "worker3", "task-3"); err != nil {
+        t.Fatalf("Publish: %v", err)
+    }
+
+    statuses := newFakeStatusSetter()
+    worker := NewWorker(client, "worker3", "consumer-3", func(ctx context.Context, taskId string) error {
+        return nil
+    })
+    worker.SetStatus = statuses.set
+
+    runCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+    defer cancel()
+    go worker.Run(runCtx)
+
+    deadline := time.After(2 * time.Second)
+    for {
+        if statuses.get("task-3") == model.StatusDone {
+            return
+        }
+        select {
+        case <-deadline:
+            t.Fatalf("got status %q, want %q", statuses.get("task-3"), model.StatusDone)
+        case <-time.After(10 * time.Millisecond):
+        }
+    }
+}
+
+func TestProcessMarksStatusFailedOnHandlerError(t *testing.T) {
+    client := newTestClient(t)
+    ctx := context.Background()
+
+    if err := Publish(ctx, client, "worker4", "task-4"); err != nil {
+        t.Fatalf("Publish: %v", err)
+    }
+
+    statuses := newFakeStatusSetter()
+    worker := NewWorker(client, "worker4", "consumer-4", func(ctx context.Context, taskId string) error {
+        return fmt.Errorf("boom")
+    })
+    worker.SetStatus = statuses.set
+
+    runCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+    defer cancel()
+    go worker.Run(runCtx)
+
+    deadline := time.After(2 * time.Second)
+    for {
+        if statuses.get("task-4") == model.StatusFailed {
+            return
+        }
+        select {
+        case <-deadline:
+            t.Fatalf("got status %q, want %q", statuses.get("task-4"), model.StatusFailed)
+        case <-time.After(10 * time.Millisecond):
+        }
+    }
+}
@@ -0,0 +1,50 @@
+// Package telemetry wires up the Prometheus metrics and OpenTelemetry traces
+// the task manager exposes, so both can be configured and imported from one
+// place instead of scattered across handlers.
+package telemetry
+
+import (
+    "net/http"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+    // HTTPRequestDuration tracks request latency by route, method, and
+    // response status for every handler.
+    HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+        Name: "http_request_duration_seconds",
+        Help: "HTTP request latency in seconds by route, method, and status.",
+    }, []string{"route", "method", "status"})
+
+    // TasksCreated, TasksDeleted, and TasksFetched count task lifecycle
+    // operations across the REST API.
+    TasksCreated = promauto.NewCounter(prometheus.CounterOpts{
+        Name: "tasks_created_total",
+        Help: "Total number of tasks created.",
+    })
+    TasksDeleted = promauto.NewCounter(prometheus.CounterOpts{
+        Name: "tasks_deleted_total",
+        Help: "Total number of tasks deleted.",
+    })
+    TasksFetched = promauto.NewCounter(prometheus.CounterOpts{
+        Name: "tasks_fetched_total",
+        Help: "Total number of task fetch requests served.",
+    })
+
+    // StreamBacklog is the number of entries in a worker's task stream,
+    // acknowledged or not, refreshed whenever a task is published to it or
+    // claimed from it through RedisStore.Consume. Entries handled by a
+    // stream.Worker do not refresh it.
+    StreamBacklog = promauto.NewGaugeVec(prometheus.GaugeOpts{
+        Name: "tasks_stream_backlog",
+        Help: "Entries in a worker's task stream, regardless of delivery state.",
+    }, []string{"worker_id"})
+)
+
+// Handler exposes the registered metrics for a /metrics route.
+func Handler() http.Handler {
+    return promhttp.Handler()
+}
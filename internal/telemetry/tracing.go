@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+    "context"
+    "os"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies this service in exported spans.
+const ServiceName = "task-manager"
+
+// Tracer is the tracer every handler and store function uses to start spans.
+var Tracer = otel.Tracer(ServiceName)
+
+// InitTracing configures the global tracer provider from
+// OTEL_EXPORTER_OTLP_ENDPOINT. With no endpoint set it installs a no-op
+// provider, so Tracer.Start stays cheap and safe to call unconditionally.
+// The returned shutdown func flushes and closes the exporter; callers should
+// defer it.
+func InitTracing(ctx context.Context) (func(context.Context) error, error) {
+    endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+    if endpoint == "" {
+        otel.SetTracerProvider(trace.NewNoopTracerProvider())
+        return func(context.Context) error { return nil }, nil
+    }
+
+    exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+    if err != nil {
+        return nil, err
+    }
+
+    res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(ServiceName)))
+    if err != nil {
+        return nil, err
+    }
+
+    tp := sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+    )
+
+    otel.SetTracerProvider(tp)
+    otel.SetTextMapPropagator(propagation.TraceContext{})
+    Tracer = otel.Tracer(ServiceName)
+
+    return tp.Shutdown, nil
+}